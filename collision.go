@@ -4,6 +4,7 @@ package lxgata
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -11,6 +12,15 @@ type CollisionType string
 
 const ELASTIC, EFFECTIVE, EXCITATION, ATTACHMENT, IONIZATION, ROTATION CollisionType = "ELASTIC", "EFFECTIVE", "EXCITATION", "ATTACHMENT", "IONIZATION", "ROTATION"
 
+// InterpolationMode selects how Collision.CrossSectionAt interpolates between tabulated points.
+type InterpolationMode int
+
+const (
+	Linear InterpolationMode = iota // linear interpolation in (energy, cross section)
+	LogLog                          // linear interpolation in (log energy, log cross section), standard for Bolsig+-style tables
+	LogLin                          // linear interpolation in (log energy, cross section)
+)
+
 // Cross section point holds cross section value in [m^2] at energy [eV]
 type CrossSectionPoint struct {
 	Energy, Value float64
@@ -21,6 +31,10 @@ type Collision struct {
 	MassRatio       float64 // ratio of electron mass to target particle, if applicable
 	Species         string  // target particle species
 	Data            []CrossSectionPoint
+	Interpolation   InterpolationMode // how CrossSectionAt interpolates between points of Data
+	Database        string            // name of the LXCat database group this collision was loaded from, if present
+	Reference       string            // "HOW TO REFERENCE" citation block of the database group, if present
+	Updated         string            // value of the process's UPDATED field, if present
 	Threshold       float64           // value of energy [eV], below which collision can not occur
 	StatWeightRatio float64           // statistical weight ratio of the upper state to the lower state (for excitations)
 	LowerEnergy     float64           // energy of lower state of rotational process (for rotations)
@@ -30,24 +44,55 @@ type Collision struct {
 	Info            map[string]string // any additional fields found in collision description
 }
 
-// CrossSectionAt calculates cross section at given energy as linear interpolation of piecewise linear cross section function.
-// If the energy is below first or beyond last data point, it assumes cross section to be constant at corresponding values.
+// CrossSectionAt calculates cross section at given energy by interpolating the piecewise cross section function
+// according to p.Interpolation. If the energy is below first or beyond last data point, it assumes cross section
+// to be constant at corresponding values.
 func (p *Collision) CrossSectionAt(energy float64) float64 {
-	l, r := 0, len(p.Data)
+	return crossSectionAt(p.Data, energy, p.Interpolation)
+}
 
-	for c := (l + r) / 2; l+1 < r; c = (l + r) / 2 {
-		if energy < p.Data[c].Energy {
+// crossSectionAt interpolates data at energy using mode, independently of any Collision's own
+// Interpolation setting. Used by CrossSectionAt and by Collision.Resample, which resamples onto a
+// new grid using a caller-chosen mode rather than the source collision's mode.
+func crossSectionAt(data []CrossSectionPoint, energy float64, mode InterpolationMode) float64 {
+	if energy <= data[0].Energy {
+		return data[0].Value
+	}
+	if last := len(data) - 1; energy >= data[last].Energy {
+		return data[last].Value
+	}
+
+	l, r := 0, len(data)-1
+	for l+1 < r {
+		c := (l + r) / 2
+		if energy < data[c].Energy {
 			r = c
 		} else {
 			l = c
 		}
 	}
-	if l == 0 || r == len(p.Data) {
-		return p.Data[l].Value
-	} else {
-		w := (energy - p.Data[l].Energy) / (p.Data[r].Energy - p.Data[l].Energy)
-		return p.Data[l].Value + (p.Data[r].Value-p.Data[l].Value)*w
+	return interpolateCrossSection(data[l], data[r], energy, mode)
+}
+
+// interpolateCrossSection interpolates between two bracketing cross section points at the given energy.
+// LogLog and LogLin fall back to Linear whenever a log is undefined: for both, when energy or either bracketing
+// energy is not strictly positive; for LogLog also when either bracketing cross section value is not strictly
+// positive, which is common just above a reaction threshold. LogLin interpolates zero values without falling back.
+func interpolateCrossSection(left, right CrossSectionPoint, energy float64, mode InterpolationMode) float64 {
+	switch mode {
+	case LogLog:
+		if energy > 0 && left.Energy > 0 && right.Energy > 0 && left.Value > 0 && right.Value > 0 {
+			w := (math.Log(energy) - math.Log(left.Energy)) / (math.Log(right.Energy) - math.Log(left.Energy))
+			return math.Exp(math.Log(left.Value) + (math.Log(right.Value)-math.Log(left.Value))*w)
+		}
+	case LogLin:
+		if energy > 0 && left.Energy > 0 && right.Energy > 0 {
+			w := (math.Log(energy) - math.Log(left.Energy)) / (math.Log(right.Energy) - math.Log(left.Energy))
+			return left.Value + (right.Value-left.Value)*w
+		}
 	}
+	w := (energy - left.Energy) / (right.Energy - left.Energy)
+	return left.Value + (right.Value-left.Value)*w
 }
 
 func (p Collision) String() string {
@@ -1,44 +1,141 @@
-// Package provides functions to load electron cross sections from files in BOLSIG / LXCat format and to interpolate cross section functions.
-// Energy is measured in electronvolts, cross sections in m^2.
 package lxgata
 
 import (
 	"bufio"
+	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 )
 
 type Collisions []Collision
 
-// LoadCrossSections loads cross section data from file in LXCat/BOLSIG format
+// lineScanner wraps bufio.Scanner with one line of pushback, so a lookahead line read to detect
+// the end of a multi-line block (e.g. a HOW TO REFERENCE citation) can be put back for the main
+// parsing loop to process.
+type lineScanner struct {
+	*bufio.Scanner
+	pending    string
+	hasPending bool
+}
+
+func (s *lineScanner) scan() bool {
+	if s.hasPending {
+		s.hasPending = false
+		return true
+	}
+	return s.Scanner.Scan()
+}
+
+func (s *lineScanner) text() string {
+	if s.hasPending {
+		return s.pending
+	}
+	return s.Scanner.Text()
+}
+
+func (s *lineScanner) unscan(line string) {
+	s.pending, s.hasPending = line, true
+}
+
+// isHeaderLine reports whether line starts a new LXCat block (a "KEY:" header or a process-type
+// line such as "ELASTIC"), used to stop a HOW TO REFERENCE citation block at the next recognized
+// header rather than only at the next blank line, since real multi-group LXCat downloads place
+// SPECIES/PROCESS lines directly after the citation with no blank line in between.
+func isHeaderLine(line string, processTypes map[string]struct{}) bool {
+	if key, _, found := strings.Cut(line, ":"); found {
+		switch strings.TrimSpace(key) {
+		case "DATABASE", "HOW TO REFERENCE", "SPECIES", "PROCESS":
+			return true
+		}
+	}
+	if tokens := strings.Fields(line); len(tokens) > 0 {
+		if _, ok := processTypes[tokens[0]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadCrossSections loads cross section data from a file in LXCat/BOLSIG format.
+// Loaded collisions use Linear interpolation; use LoadCrossSectionsWithMode to pick another default.
 func LoadCrossSections(fileName string) (Collisions, error) {
+	return LoadCrossSectionsWithMode(fileName, Linear)
+}
+
+// LoadCrossSectionsWithMode loads cross section data from a file in LXCat/BOLSIG format, setting
+// defaultMode as the Collision.Interpolation of every loaded collision.
+func LoadCrossSectionsWithMode(fileName string, defaultMode InterpolationMode) (Collisions, error) {
 	file, err := os.Open(fileName)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
+	return LoadCrossSectionsFromWithMode(file, defaultMode)
+}
+
+// LoadCrossSectionsFrom loads cross section data from r in LXCat/BOLSIG format.
+// Loaded collisions use Linear interpolation; use LoadCrossSectionsFromWithMode to pick another default.
+func LoadCrossSectionsFrom(r io.Reader) (Collisions, error) {
+	return LoadCrossSectionsFromWithMode(r, Linear)
+}
+
+// LoadCrossSectionsFromWithMode loads cross section data from r in LXCat/BOLSIG format, setting
+// defaultMode as the Collision.Interpolation of every loaded collision. r may be the concatenation
+// of several LXCat database downloads; the "DATABASE:" and "HOW TO REFERENCE:" header lines that
+// precede each group of processes are carried over onto every Collision parsed from that group,
+// so that provenance survives loading from embed.FS, HTTP responses or gzip streams alike.
+func LoadCrossSectionsFromWithMode(r io.Reader, defaultMode InterpolationMode) (Collisions, error) {
 	setProcessTypes := map[string]struct{}{string(ELASTIC): {}, string(EFFECTIVE): {}, string(EXCITATION): {}, string(ATTACHMENT): {}, string(IONIZATION): {}, string(ROTATION): {}}
 
 	var collisions []Collision
+	var database, reference string
+	var err error
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
+	scanner := &lineScanner{Scanner: bufio.NewScanner(r)}
+	for scanner.scan() {
+		line := scanner.text()
 		tokens := strings.Fields(line)
 		if len(tokens) == 0 {
 			continue
 		}
 
+		if key, val, found := strings.Cut(line, ":"); found && strings.TrimSpace(key) == "DATABASE" {
+			database = strings.TrimSpace(val)
+			reference = "" // a new database group may omit HOW TO REFERENCE; don't inherit the previous group's citation
+			continue
+		}
+
+		if key, val, found := strings.Cut(line, ":"); found && strings.TrimSpace(key) == "HOW TO REFERENCE" {
+			var citation []string
+			if v := strings.TrimSpace(val); v != "" {
+				citation = append(citation, v)
+			}
+			for scanner.scan() {
+				next := scanner.text()
+				if strings.TrimSpace(next) == "" {
+					break
+				}
+				if isHeaderLine(next, setProcessTypes) {
+					scanner.unscan(next)
+					break
+				}
+				citation = append(citation, strings.TrimSpace(next))
+			}
+			reference = strings.Join(citation, " ")
+			continue
+		}
+
 		if _, ok := setProcessTypes[tokens[0]]; ok {
 			collisionType := CollisionType(tokens[0])
 
-			scanner.Scan()
-			species, _, _ := strings.Cut(scanner.Text(), " ")
+			scanner.scan()
+			species, _, _ := strings.Cut(scanner.text(), " ")
 
-			scanner.Scan()
-			parameters := strings.Fields(strings.Trim(scanner.Text(), " "))
+			scanner.scan()
+			parameters := strings.Fields(strings.Trim(scanner.text(), " "))
 
 			var massRatio, threshold, lowerEnergy, upperEnergy, lowerStatWeight, upperStatWeight float64
 			statWeightRatio := 1.
@@ -79,8 +176,8 @@ func LoadCrossSections(fileName string) (Collisions, error) {
 				if err != nil {
 					return nil, err
 				}
-				scanner.Scan()
-				parameters = strings.Fields(scanner.Text())
+				scanner.scan()
+				parameters = strings.Fields(scanner.text())
 				upperEnergy, err = strconv.ParseFloat(parameters[0], 64)
 				if err != nil {
 					return nil, err
@@ -92,18 +189,20 @@ func LoadCrossSections(fileName string) (Collisions, error) {
 			}
 
 			info := make(map[string]string)
-			for !strings.HasPrefix(scanner.Text(), "-----") {
-				key, val, found := strings.Cut(scanner.Text(), ":")
+			for !strings.HasPrefix(scanner.text(), "-----") {
+				key, val, found := strings.Cut(scanner.text(), ":")
 				if found {
 					info[strings.Trim(key, " ")] = strings.Trim(val, " ")
 				}
-				scanner.Scan()
+				scanner.scan()
 			}
-			scanner.Scan()
+			updated := info["UPDATED"]
+			delete(info, "UPDATED")
+			scanner.scan()
 
 			var data []CrossSectionPoint
-			for !strings.HasPrefix(scanner.Text(), "-----") {
-				crossSectionPoint := strings.Fields(scanner.Text())
+			for !strings.HasPrefix(scanner.text(), "-----") {
+				crossSectionPoint := strings.Fields(scanner.text())
 				energy, err := strconv.ParseFloat(crossSectionPoint[0], 64)
 				if err != nil {
 					return nil, err
@@ -116,7 +215,7 @@ func LoadCrossSections(fileName string) (Collisions, error) {
 				if !(collisionType == IONIZATION || collisionType == EXCITATION || collisionType == ROTATION) || threshold < energy {
 					data = append(data, CrossSectionPoint{energy, crossSection})
 				}
-				scanner.Scan()
+				scanner.scan()
 			}
 
 			if (collisionType == IONIZATION || collisionType == EXCITATION || collisionType == ROTATION) && data[0].Value > 0. {
@@ -128,6 +227,10 @@ func LoadCrossSections(fileName string) (Collisions, error) {
 				MassRatio:       massRatio,
 				Species:         species,
 				Data:            data,
+				Interpolation:   defaultMode,
+				Database:        database,
+				Reference:       reference,
+				Updated:         updated,
 				Threshold:       threshold,
 				StatWeightRatio: statWeightRatio,
 				LowerEnergy:     lowerEnergy,
@@ -141,7 +244,8 @@ func LoadCrossSections(fileName string) (Collisions, error) {
 	return collisions, nil
 }
 
-// TotalCrossSectionAt returns total cross section at given energy for all species and processes in collisions set
+// TotalCrossSectionAt returns total cross section at given energy for all species and processes in collisions set.
+// Each collision is interpolated according to its own Interpolation mode.
 func (colls Collisions) TotalCrossSectionAt(energy float64) float64 {
 	var result float64
 	for _, collision := range colls {
@@ -150,7 +254,8 @@ func (colls Collisions) TotalCrossSectionAt(energy float64) float64 {
 	return result
 }
 
-// TotalCrossSectionOfKindAt returns summed cross section of given type at given energy for all species and processes in collision set
+// TotalCrossSectionOfKindAt returns summed cross section of given type at given energy for all species and processes
+// in collision set. Each collision is interpolated according to its own Interpolation mode.
 func (colls Collisions) TotalCrossSectionOfKindAt(t CollisionType, energy float64) float64 {
 	var result float64
 	for _, collision := range colls {
@@ -161,6 +266,35 @@ func (colls Collisions) TotalCrossSectionOfKindAt(t CollisionType, energy float6
 	return result
 }
 
+// RateCoefficients evaluates Collision.MaxwellianRate at electron temperature Te for every collision in
+// the set, keyed by "species/process", and returns the results summed by key.
+func (colls Collisions) RateCoefficients(Te float64) map[string]float64 {
+	result := make(map[string]float64)
+	for _, collision := range colls {
+		key := collision.Species + "/" + string(collision.Type)
+		result[key] += collision.MaxwellianRate(Te)
+	}
+	return result
+}
+
+// UnionEnergyGrid returns the sorted, de-duplicated union of the tabulated Data energies of every
+// collision in colls. Numerical solvers (Boltzmann, fluid, MCC precomputation) can resample all
+// processes onto this shared mesh with Collisions.ResampleAll.
+func (colls Collisions) UnionEnergyGrid() []float64 {
+	seen := make(map[float64]struct{})
+	var grid []float64
+	for i := range colls {
+		for _, p := range colls[i].Data {
+			if _, ok := seen[p.Energy]; !ok {
+				seen[p.Energy] = struct{}{}
+				grid = append(grid, p.Energy)
+			}
+		}
+	}
+	sort.Float64s(grid)
+	return grid
+}
+
 // SurplusCrossSection returns sum of maximum values of cross sections over all processes in collision set
 // Can be used to estimate lower bound on mean free path
 func (colls Collisions) SurplusCrossSection() float64 {
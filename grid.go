@@ -0,0 +1,48 @@
+package lxgata
+
+import "math"
+
+// GeometricGrid returns n log-spaced energies from Emin to Emax inclusive:
+// Emin·(Emax/Emin)^(i/(n-1)), the standard mesh for eV-to-keV cross section tabulation.
+// For n <= 1 it returns []float64{Emin}.
+func GeometricGrid(Emin, Emax float64, n int) []float64 {
+	if n <= 1 {
+		return []float64{Emin}
+	}
+	grid := make([]float64, n)
+	ratio := Emax / Emin
+	for i := range grid {
+		grid[i] = Emin * math.Pow(ratio, float64(i)/float64(n-1))
+	}
+	return grid
+}
+
+// Resample returns a copy of p whose Data is sampled on grid, interpolating the original Data
+// with the given mode. For EXCITATION/IONIZATION/ROTATION, a grid point exactly at p.Threshold is
+// preserved as an exact zero, matching the threshold point LoadCrossSections injects.
+func (p Collision) Resample(grid []float64, mode InterpolationMode) Collision {
+	data := make([]CrossSectionPoint, len(grid))
+	for i, e := range grid {
+		data[i] = CrossSectionPoint{Energy: e, Value: crossSectionAt(p.Data, e, mode)}
+	}
+	if p.Type == EXCITATION || p.Type == IONIZATION || p.Type == ROTATION {
+		for i := range data {
+			if data[i].Energy == p.Threshold {
+				data[i].Value = 0
+			}
+		}
+	}
+
+	p.Data = data
+	p.Interpolation = mode
+	return p
+}
+
+// ResampleAll resamples every collision in colls onto grid, as Collision.Resample does.
+func (colls Collisions) ResampleAll(grid []float64, mode InterpolationMode) Collisions {
+	result := make(Collisions, len(colls))
+	for i := range colls {
+		result[i] = colls[i].Resample(grid, mode)
+	}
+	return result
+}
@@ -1,7 +1,10 @@
 package lxgata
 
 import (
+	"bytes"
 	"math"
+	"math/rand"
+	"reflect"
 	"testing"
 )
 
@@ -57,7 +60,7 @@ func TestCrossSectionAt(t *testing.T) {
 	}
 
 	for name, test := range tests {
-		test := test // NOTE: uncomment for Go < 1.22, see /doc/faq#closures_and_goroutines
+		name, test := name, test // NOTE: uncomment for Go < 1.22, see /doc/faq#closures_and_goroutines
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			if got, expected := collision.CrossSectionAt(test.input), test.result; math.Abs(got-expected) > eps {
@@ -66,3 +69,269 @@ func TestCrossSectionAt(t *testing.T) {
 		})
 	}
 }
+
+func TestCrossSectionAtLogInterpolation(t *testing.T) {
+	eps := 1e-9
+	tests := map[string]struct {
+		data          []CrossSectionPoint
+		mode          InterpolationMode
+		input, result float64
+	}{
+		"LogLog on a strictly positive bracket interpolates log-linearly": {
+			data:   []CrossSectionPoint{{1.0e+1, 1.0e-20}, {1.0e+2, 1.0e-18}},
+			mode:   LogLog,
+			input:  31.6227766016838, // sqrt(10*100), the geometric midpoint
+			result: 1.0e-19,          // geometric midpoint of the bracketing values
+		},
+		"LogLog falls back to Linear when a bracket value is zero": {
+			data:   []CrossSectionPoint{{1.0e+1, 0}, {1.0e+2, 1.0e-18}},
+			mode:   LogLog,
+			input:  55,
+			result: 0.5 * 1.0e-18, // linear in energy: (55-10)/(100-10) == 0.5
+		},
+		"LogLog falls back to Linear when energy is zero": {
+			data:   []CrossSectionPoint{{-10, 1.0e-20}, {10, 1.0e-18}},
+			mode:   LogLog,
+			input:  0,
+			result: 0.5 * (1.0e-20 + 1.0e-18), // linear in energy: (0-(-10))/(10-(-10)) == 0.5
+		},
+		"LogLin interpolates linearly in value over log energy": {
+			data:   []CrossSectionPoint{{1.0e+1, 0}, {1.0e+2, 2}},
+			mode:   LogLin,
+			input:  31.6227766016838, // sqrt(10*100), the geometric midpoint
+			result: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := crossSectionAt(test.data, test.input, test.mode)
+			if relErr := math.Abs(got-test.result) / math.Abs(test.result); relErr > eps {
+				t.Fatalf("crossSectionAt(%v, %v) = %v; expected %v", test.input, test.mode, got, test.result)
+			}
+		})
+	}
+}
+
+func TestWriteToRoundTrip(t *testing.T) {
+	original, err := LoadCrossSections("LXCat_format_test.txt")
+	if err != nil {
+		t.Fatalf("Unable to load cross sections %v", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if _, err := original.WriteTo(&buf); err != nil {
+		t.Fatalf("Unable to write cross sections %v", err.Error())
+	}
+
+	reloaded, err := LoadCrossSectionsFrom(&buf)
+	if err != nil {
+		t.Fatalf("Unable to reload written cross sections %v", err.Error())
+	}
+
+	if !reflect.DeepEqual(original, reloaded) {
+		t.Fatalf("round-tripped collisions do not match original:\noriginal: %#v\nreloaded: %#v", original, reloaded)
+	}
+}
+
+func TestGeometricGrid(t *testing.T) {
+	if got := GeometricGrid(1, 100, 1); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("GeometricGrid(1, 100, 1) = %v; expected [1]", got)
+	}
+
+	grid := GeometricGrid(1, 100, 3)
+	want := []float64{1, 10, 100}
+	eps := 1e-9
+	for i := range want {
+		if math.Abs(grid[i]-want[i]) > eps {
+			t.Fatalf("GeometricGrid(1, 100, 3) = %v; expected %v", grid, want)
+		}
+	}
+}
+
+func TestResamplePreservesThreshold(t *testing.T) {
+	collision := Collision{
+		Type:      IONIZATION,
+		Threshold: 15.76,
+		Data:      []CrossSectionPoint{{15.76, 0}, {20, 1e-20}, {100, 5e-20}},
+	}
+
+	grid := []float64{15.76, 18, 50}
+	resampled := collision.Resample(grid, LogLog)
+
+	if resampled.Data[0].Value != 0 {
+		t.Fatalf("threshold point was not preserved as an exact zero: %v", resampled.Data[0])
+	}
+	if resampled.Data[1].Value <= 0 || resampled.Data[1].Value >= resampled.Data[2].Value {
+		t.Fatalf("interior resampled point looks wrong: %v", resampled.Data)
+	}
+}
+
+func TestUnionEnergyGrid(t *testing.T) {
+	colls := Collisions{
+		{Data: []CrossSectionPoint{{1, 0}, {10, 1}}},
+		{Data: []CrossSectionPoint{{5, 0}, {10, 1}, {20, 2}}},
+	}
+	grid := colls.UnionEnergyGrid()
+	want := []float64{1, 5, 10, 20}
+	if len(grid) != len(want) {
+		t.Fatalf("UnionEnergyGrid() = %v; expected %v", grid, want)
+	}
+	for i := range want {
+		if grid[i] != want[i] {
+			t.Fatalf("UnionEnergyGrid() = %v; expected %v", grid, want)
+		}
+	}
+}
+
+func TestMaxwellianRateAccuracy(t *testing.T) {
+	// For a constant cross section sigma0, k = <v> sigma0 = sigma0 * sqrt(8e*Te/(pi*me)) is an
+	// exact closed form independent of RateCoefficient's own integration machinery, so it catches
+	// normalization mistakes in MaxwellianRate that a self-referential brute-force check would miss.
+	// The first point sits at a finite energy (not ~0) so the test also exercises the [0,
+	// Data[0].Energy) head of the integral, not just the tabulated range and the tail.
+	const sigma0 = 4.0e-19
+	collision := Collision{
+		Type: ELASTIC,
+		Data: []CrossSectionPoint{
+			{1.0, sigma0},
+			{1.0e+2, sigma0},
+		},
+	}
+
+	for _, Te := range []float64{0.3, 1, 2, 5} {
+		got := collision.MaxwellianRate(Te)
+		want := sigma0 * math.Sqrt(8*electronCharge*Te/(math.Pi*electronMass))
+		if relErr := math.Abs(got-want) / want; relErr > 1e-3 {
+			t.Fatalf("Te=%v: MaxwellianRate=%v, analytic constant-sigma reference=%v, relative error %v exceeds 1e-3", Te, got, want, relErr)
+		}
+	}
+}
+
+func TestLoadCrossSectionsMultiGroupHeader(t *testing.T) {
+	cs, err := LoadCrossSections("LXCat_multigroup_test.txt")
+	if err != nil {
+		t.Fatalf("Unable to load cross sections %v", err.Error())
+	}
+	if len(cs) != 2 {
+		t.Fatalf("expected 2 collisions, got %v", len(cs))
+	}
+
+	wantReference := "A. V. Phelps, compilation see http://jila.colorado.edu"
+	if cs[0].Database != "Phelps database" {
+		t.Fatalf("Database = %q; expected %q", cs[0].Database, "Phelps database")
+	}
+	if cs[0].Reference != wantReference {
+		t.Fatalf("Reference = %q; expected %q (SPECIES/PROCESS lines must not be swallowed into it)", cs[0].Reference, wantReference)
+	}
+
+	// The second group has its own DATABASE: but no HOW TO REFERENCE:, so it must not inherit the
+	// first group's citation.
+	if cs[1].Database != "Morgan database" {
+		t.Fatalf("Database = %q; expected %q", cs[1].Database, "Morgan database")
+	}
+	if cs[1].Reference != "" {
+		t.Fatalf("Reference = %q; expected empty (must not inherit the previous group's HOW TO REFERENCE)", cs[1].Reference)
+	}
+}
+
+func TestPostCollisionKinematics(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const E = 10.0
+
+	t.Run("ELASTIC loses a small mass-ratio-scaled fraction of E", func(t *testing.T) {
+		c := Collision{Type: ELASTIC, MassRatio: 1e-4}
+		out := c.PostCollision(E, rng)
+		if out.Terminated {
+			t.Fatalf("ELASTIC should not terminate")
+		}
+		if out.Energy <= 0 || out.Energy >= E {
+			t.Fatalf("PostCollision(%v) = %v; expected energy in (0, %v)", E, out.Energy, E)
+		}
+	})
+
+	t.Run("EXCITATION subtracts Threshold", func(t *testing.T) {
+		c := Collision{Type: EXCITATION, Threshold: 4}
+		if out := c.PostCollision(E, rng); out.Energy != E-4 {
+			t.Fatalf("PostCollision(%v) = %v; expected %v", E, out.Energy, E-4)
+		}
+	})
+
+	t.Run("IONIZATION clamps at 0 when E is below Threshold", func(t *testing.T) {
+		c := Collision{Type: IONIZATION, Threshold: 15}
+		if out := c.PostCollision(E, rng); out.Energy != 0 {
+			t.Fatalf("PostCollision(%v) = %v; expected 0 (E below Threshold)", E, out.Energy)
+		}
+	})
+
+	t.Run("ROTATION subtracts UpperEnergy-LowerEnergy, not Threshold", func(t *testing.T) {
+		c := Collision{Type: ROTATION, Threshold: 0, LowerEnergy: 0.01, UpperEnergy: 0.03}
+		want := E - (0.03 - 0.01)
+		if out := c.PostCollision(E, rng); out.Energy != want {
+			t.Fatalf("PostCollision(%v) = %v; expected %v", E, out.Energy, want)
+		}
+	})
+
+	t.Run("ATTACHMENT terminates", func(t *testing.T) {
+		c := Collision{Type: ATTACHMENT}
+		if out := c.PostCollision(E, rng); !out.Terminated {
+			t.Fatalf("ATTACHMENT should terminate")
+		}
+	})
+
+	t.Run("EFFECTIVE conserves energy", func(t *testing.T) {
+		c := Collision{Type: EFFECTIVE}
+		if out := c.PostCollision(E, rng); out.Energy != E {
+			t.Fatalf("PostCollision(%v) = %v; expected %v", E, out.Energy, E)
+		}
+	})
+}
+
+func TestMCCSamplerStatistics(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	collA := Collision{Type: ELASTIC, Data: []CrossSectionPoint{{0, 1.0e-20}, {10, 5.0e-20}}}
+	collB := Collision{Type: EFFECTIVE, Data: []CrossSectionPoint{{0, 2.0e-20}, {10, 2.0e-20}}}
+	colls := Collisions{collA, collB}
+
+	const E, N = 5.0, 1.0e20
+	sampler := NewMCCSampler(colls, 10)
+
+	sigmaMax := sampler.sigmaMaxAt(E)
+	sigmaA, sigmaB := collA.CrossSectionAt(E), collB.CrossSectionAt(E)
+	if sigmaMax <= sigmaA+sigmaB {
+		t.Fatalf("test fixture must have a non-trivial null fraction: sigmaMax=%v, sigmaA+sigmaB=%v", sigmaMax, sigmaA+sigmaB)
+	}
+
+	const n = 500000
+	var countA, countB, countNull int
+	var sumFreePathTime float64
+	for i := 0; i < n; i++ {
+		if idx, isNull := sampler.SampleCollision(E, rng); isNull {
+			countNull++
+		} else if idx == 0 {
+			countA++
+		} else {
+			countB++
+		}
+		sumFreePathTime += sampler.FreePathTime(E, N, rng)
+	}
+
+	const eps = 5e-3
+	checkFraction := func(name string, got int, want float64) {
+		if frac := float64(got) / n; math.Abs(frac-want) > eps {
+			t.Fatalf("%s empirical fraction = %v; expected %v (+/- %v)", name, frac, want, eps)
+		}
+	}
+	checkFraction("process A", countA, sigmaA/sigmaMax)
+	checkFraction("process B", countB, sigmaB/sigmaMax)
+	checkFraction("null collision", countNull, 1-(sigmaA+sigmaB)/sigmaMax)
+
+	// FreePathTime draws -ln(U)/(N*v*sigmaMax) with U uniform on (0, 1], so E[-ln(U)] = 1 and the
+	// empirical mean free path time should converge to 1/(N*v(E)*sigmaMax).
+	wantMeanFreePathTime := 1 / (N * electronVelocity(E) * sigmaMax)
+	gotMeanFreePathTime := sumFreePathTime / n
+	if relErr := math.Abs(gotMeanFreePathTime-wantMeanFreePathTime) / wantMeanFreePathTime; relErr > eps {
+		t.Fatalf("mean FreePathTime = %v; expected %v (relative error %v exceeds %v)", gotMeanFreePathTime, wantMeanFreePathTime, relErr, eps)
+	}
+}
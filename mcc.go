@@ -0,0 +1,145 @@
+package lxgata
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// MCCSampler implements Skullerud's null-collision method: it precomputes a piecewise-constant
+// upper bound on the total cross section so that, at simulation time, free flight times and
+// collision processes can be sampled without re-scanning every Collision's tabulated data.
+type MCCSampler struct {
+	Collisions   Collisions
+	Grid         []float64 // energy grid edges covering [0, Emax], ascending
+	CellSigmaMax []float64 // per cell k=[Grid[k], Grid[k+1]]: Σ_i max over the cell of σ_i(E)
+}
+
+// NewMCCSampler precomputes σ_max(E) for colls as a step function over [0, Emax]. The grid is the
+// union of all tabulated energies of colls clipped to [0, Emax]; since CrossSectionAt interpolates
+// monotonically between two adjacent tabulated points of its own collision, the true per-cell
+// supremum of each σ_i is attained at a cell edge, so no oversampling is needed.
+func NewMCCSampler(colls Collisions, Emax float64) *MCCSampler {
+	grid := unionEnergyGridUpTo(colls, Emax)
+
+	cellSigmaMax := make([]float64, len(grid)-1)
+	for k := 0; k+1 < len(grid); k++ {
+		var total float64
+		for i := range colls {
+			left := colls[i].CrossSectionAt(grid[k])
+			right := colls[i].CrossSectionAt(grid[k+1])
+			if right > left {
+				total += right
+			} else {
+				total += left
+			}
+		}
+		cellSigmaMax[k] = total
+	}
+
+	return &MCCSampler{Collisions: colls, Grid: grid, CellSigmaMax: cellSigmaMax}
+}
+
+// sigmaMaxAt returns the precomputed Σσ_max for the cell containing energy.
+func (s *MCCSampler) sigmaMaxAt(energy float64) float64 {
+	l, r := 0, len(s.Grid)-1
+	for c := (l + r) / 2; l+1 < r; c = (l + r) / 2 {
+		if energy < s.Grid[c] {
+			r = c
+		} else {
+			l = c
+		}
+	}
+	if l >= len(s.CellSigmaMax) {
+		l = len(s.CellSigmaMax) - 1
+	}
+	return s.CellSigmaMax[l]
+}
+
+// electronVelocity returns the electron velocity [m/s] corresponding to kinetic energy E [eV].
+func electronVelocity(E float64) float64 {
+	return math.Sqrt(2 * electronCharge * E / electronMass)
+}
+
+// FreePathTime samples a free flight time [s] for an electron of energy E [eV] through a gas of
+// number density N [m^-3], using the precomputed upper bound on total cross section:
+//
+//	t = -ln(U) / (N · v(E) · Σσ_max)
+func (s *MCCSampler) FreePathTime(E, N float64, rng *rand.Rand) float64 {
+	u := 1 - rng.Float64() // U in (0, 1], avoids ln(0)
+	return -math.Log(u) / (N * electronVelocity(E) * s.sigmaMaxAt(E))
+}
+
+// SampleCollision picks which collision occurs at energy E, or reports a null collision. idx
+// indexes s.Collisions and is -1 when isNull is true. A process is chosen with probability
+// proportional to its actual cross section σ_i(E); the draw is null (no real collision, isNull
+// true) with the remaining probability 1 - Σσ_i(E)/Σσ_max, which corrects the over-estimated
+// collision frequency implied by sigmaMaxAt back down to the true one.
+func (s *MCCSampler) SampleCollision(E float64, rng *rand.Rand) (idx int, isNull bool) {
+	sigmaMax := s.sigmaMaxAt(E)
+	if sigmaMax <= 0 {
+		return -1, true
+	}
+
+	u := rng.Float64() * sigmaMax
+
+	var acc float64
+	for i := range s.Collisions {
+		acc += s.Collisions[i].CrossSectionAt(E)
+		if u < acc {
+			return i, false
+		}
+	}
+	return -1, true
+}
+
+// CollisionOutcome describes the electron state after a sampled collision.
+type CollisionOutcome struct {
+	Energy     float64 // post-collision electron energy [eV], meaningless when Terminated
+	CosTheta   float64 // cosine of the scattering angle in the lab frame, meaningless when Terminated
+	Terminated bool    // true for ATTACHMENT: the electron is absorbed and the track ends
+}
+
+// PostCollision samples the outcome of a collision of type p occurring at incident energy E [eV]:
+// isotropic scattering with the Skullerud/MCC elastic energy loss 2·(me/M)·(1-cosθ)·E for ELASTIC,
+// threshold subtraction for EXCITATION/IONIZATION, the upper-minus-lower state energy gap for
+// ROTATION (p.Threshold is never populated for rotational processes, see collisionSet.go), and
+// termination for ATTACHMENT.
+func (p *Collision) PostCollision(E float64, rng *rand.Rand) CollisionOutcome {
+	switch p.Type {
+	case ATTACHMENT:
+		return CollisionOutcome{Terminated: true}
+	case ELASTIC:
+		cosTheta := isotropicCosTheta(rng)
+		return CollisionOutcome{Energy: E * (1 - 2*p.MassRatio*(1-cosTheta)), CosTheta: cosTheta}
+	case EXCITATION, IONIZATION:
+		return CollisionOutcome{Energy: math.Max(E-p.Threshold, 0), CosTheta: isotropicCosTheta(rng)}
+	case ROTATION:
+		return CollisionOutcome{Energy: math.Max(E-(p.UpperEnergy-p.LowerEnergy), 0), CosTheta: isotropicCosTheta(rng)}
+	default: // EFFECTIVE: isotropic scattering, no net energy loss modeled here
+		return CollisionOutcome{Energy: E, CosTheta: isotropicCosTheta(rng)}
+	}
+}
+
+// isotropicCosTheta samples the cosine of a scattering angle drawn isotropically.
+func isotropicCosTheta(rng *rand.Rand) float64 {
+	return 1 - 2*rng.Float64()
+}
+
+// unionEnergyGridUpTo returns Collisions.UnionEnergyGrid clipped to [0, Emax], with both endpoints
+// guaranteed present.
+func unionEnergyGridUpTo(colls Collisions, Emax float64) []float64 {
+	seen := map[float64]struct{}{0: {}, Emax: {}}
+	grid := []float64{0, Emax}
+	for _, e := range colls.UnionEnergyGrid() {
+		if e <= 0 || e >= Emax {
+			continue
+		}
+		if _, ok := seen[e]; !ok {
+			seen[e] = struct{}{}
+			grid = append(grid, e)
+		}
+	}
+	sort.Float64s(grid)
+	return grid
+}
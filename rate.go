@@ -0,0 +1,102 @@
+package lxgata
+
+import "math"
+
+// electronCharge and electronMass are SI physical constants used to convert a cross section
+// tabulated in (eV, m^2) into a velocity-averaged rate coefficient in m^3/s.
+const (
+	electronCharge = 1.602176634e-19  // C
+	electronMass   = 9.1093837015e-31 // kg
+)
+
+// RateCoefficient computes the velocity-averaged rate coefficient
+//
+//	k = sqrt(2e/me) * ∫ E σ(E) f(E) dE
+//
+// for the given electron energy distribution function eedf, normalized so that ∫ √E f(E) dE = 1
+// (electron energy E in eV, the Bolsig+ convention). The tabulated part of σ is integrated with
+// adaptive Simpson's rule over p.Data, interval by interval, which also takes care of the
+// threshold step injected by LoadCrossSections for EXCITATION/IONIZATION/ROTATION (σ is zero
+// there, contributing nothing). Below the first tabulated point and beyond the last, σ is assumed
+// constant at the respective endpoint value; the head is integrated directly from 0 and the tail
+// via the substitution u = 1/E, mapping it onto a finite interval.
+func (p *Collision) RateCoefficient(eedf func(E float64) float64) float64 {
+	if len(p.Data) == 0 {
+		return 0
+	}
+
+	integrand := func(E float64) float64 {
+		return E * p.CrossSectionAt(E) * eedf(E)
+	}
+
+	var sum float64
+	first := p.Data[0]
+	if first.Value > 0 && first.Energy > 0 {
+		sum += adaptiveSimpson(integrand, 0, first.Energy)
+	}
+	for i := 0; i+1 < len(p.Data); i++ {
+		sum += adaptiveSimpson(integrand, p.Data[i].Energy, p.Data[i+1].Energy)
+	}
+
+	last := p.Data[len(p.Data)-1]
+	if last.Value > 0 && last.Energy > 0 {
+		tailIntegrand := func(u float64) float64 {
+			if u <= 0 {
+				return 0 // the limit of E·f(E)/E² as E→∞ (u→0) for any normalizable f
+			}
+			E := 1 / u
+			return last.Value * E * eedf(E) / (u * u)
+		}
+		sum += adaptiveSimpson(tailIntegrand, 0, 1/last.Energy)
+	}
+
+	return math.Sqrt(2*electronCharge/electronMass) * sum
+}
+
+// MaxwellianRate is a convenience wrapper around RateCoefficient for a Maxwellian electron
+// energy distribution at electron temperature Te (eV), normalized so that ∫ √E f(E) dE = 1
+// as RateCoefficient requires:
+//
+//	f(E) = (2/√π) · Te^(-3/2) · exp(-E/Te)
+func (p *Collision) MaxwellianRate(Te float64) float64 {
+	return p.RateCoefficient(func(E float64) float64 {
+		return (2 / math.Sqrt(math.Pi)) * math.Pow(Te, -1.5) * math.Exp(-E/Te)
+	})
+}
+
+const (
+	simpsonRelTolerance = 1e-9 // relative to the magnitude of the integral estimate
+	simpsonAbsFloor     = 1e-300
+	simpsonMaxDepth     = 30
+)
+
+// adaptiveSimpson integrates f over [a, b] using recursive adaptive Simpson's rule. The error
+// budget is relative to the magnitude of the whole-interval estimate: integrands here (E·σ(E)·f(E)
+// in SI units) are typically of order 1e-19–1e-23, so a fixed absolute tolerance would either
+// never refine (too loose) or never terminate (too tight).
+func adaptiveSimpson(f func(float64) float64, a, b float64) float64 {
+	fa, fb := f(a), f(b)
+	m := (a + b) / 2
+	fm := f(m)
+	whole := simpsonRule(a, b, fa, fm, fb)
+	tolerance := simpsonRelTolerance*math.Abs(whole) + simpsonAbsFloor
+	return adaptiveSimpsonRecurse(f, a, b, fa, fm, fb, whole, tolerance, simpsonMaxDepth)
+}
+
+func simpsonRule(a, b, fa, fm, fb float64) float64 {
+	return (b - a) / 6 * (fa + 4*fm + fb)
+}
+
+func adaptiveSimpsonRecurse(f func(float64) float64, a, b, fa, fm, fb, whole, tolerance float64, depth int) float64 {
+	mid := (a + b) / 2
+	lm, rm := (a+mid)/2, (mid+b)/2
+	flm, frm := f(lm), f(rm)
+	left := simpsonRule(a, mid, fa, flm, fm)
+	right := simpsonRule(mid, b, fm, frm, fb)
+	delta := left + right - whole
+	if depth <= 0 || math.Abs(delta) <= 15*tolerance {
+		return left + right + delta/15
+	}
+	return adaptiveSimpsonRecurse(f, a, mid, fa, flm, fm, left, tolerance/2, depth-1) +
+		adaptiveSimpsonRecurse(f, mid, b, fm, frm, fb, right, tolerance/2, depth-1)
+}
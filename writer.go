@@ -0,0 +1,143 @@
+package lxgata
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// WriteOption controls how Collisions.WriteTo emits the threshold point that LoadCrossSections
+// injects at (Threshold, 0.) for EXCITATION/IONIZATION/ROTATION processes.
+type WriteOption int
+
+const (
+	KeepInjectedThreshold WriteOption = iota // write the injected (Threshold, 0.) point back out
+	DropInjectedThreshold                    // omit it; LoadCrossSections will re-inject it on reload
+)
+
+const separator = "-----------------------------"
+
+// WriteTo writes colls in BOLSIG/LXCat format to w, preserving any injected threshold point.
+// It implements io.WriterTo.
+func (colls Collisions) WriteTo(w io.Writer) (int64, error) {
+	return colls.WriteToWithOption(w, KeepInjectedThreshold)
+}
+
+// WriteToWithOption writes colls in BOLSIG/LXCat format to w as WriteTo does, but drops the
+// injected (Threshold, 0.) point when opt is DropInjectedThreshold.
+func (colls Collisions) WriteToWithOption(w io.Writer, opt WriteOption) (int64, error) {
+	var total int64
+	write := func(format string, a ...any) error {
+		n, err := fmt.Fprintf(w, format, a...)
+		total += int64(n)
+		return err
+	}
+
+	var lastDatabase, lastReference string
+	for i := range colls {
+		c := &colls[i]
+
+		if c.Database != lastDatabase || c.Reference != lastReference {
+			if c.Database != "" {
+				if err := write("DATABASE: %s\n", c.Database); err != nil {
+					return total, err
+				}
+			}
+			if c.Reference != "" {
+				if err := write("HOW TO REFERENCE: %s\n\n", c.Reference); err != nil {
+					return total, err
+				}
+			}
+			lastDatabase, lastReference = c.Database, c.Reference
+		}
+
+		if err := write("\n%s\n%s\n", c.Type, c.Species); err != nil {
+			return total, err
+		}
+
+		if err := writeParameters(write, c); err != nil {
+			return total, err
+		}
+
+		for _, key := range sortedKeys(c.Info) {
+			if err := write("%s: %s\n", key, c.Info[key]); err != nil {
+				return total, err
+			}
+		}
+		if c.Updated != "" {
+			if err := write("UPDATED: %s\n", c.Updated); err != nil {
+				return total, err
+			}
+		}
+
+		if err := write("%s\n", separator); err != nil {
+			return total, err
+		}
+
+		data := c.Data
+		if opt == DropInjectedThreshold && hasInjectedThreshold(c) {
+			data = data[1:]
+		}
+		for _, p := range data {
+			if err := write("%.6e\t%.6e\n", p.Energy, p.Value); err != nil {
+				return total, err
+			}
+		}
+
+		if err := write("%s\n", separator); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// writeParameters writes the parameter line(s) that follow the species line, matching the layout
+// LoadCrossSections expects for c.Type.
+func writeParameters(write func(format string, a ...any) error, c *Collision) error {
+	switch c.Type {
+	case ELASTIC, EFFECTIVE:
+		return write("%.6e\n", c.MassRatio)
+	case EXCITATION:
+		return write("%.6e\t%.6e\n", c.Threshold, c.StatWeightRatio)
+	case IONIZATION:
+		return write("%.6e\n", c.Threshold)
+	case ROTATION:
+		if err := write("%.6e\t%.6e\n", c.LowerEnergy, c.LowerStatWeight); err != nil {
+			return err
+		}
+		return write("%.6e\t%.6e\n", c.UpperEnergy, c.UpperStatWeight)
+	}
+	return nil
+}
+
+// hasInjectedThreshold reports whether c.Data starts with the (Threshold, 0.) point that
+// LoadCrossSections injects for EXCITATION/IONIZATION/ROTATION processes.
+func hasInjectedThreshold(c *Collision) bool {
+	if c.Type != EXCITATION && c.Type != IONIZATION && c.Type != ROTATION {
+		return false
+	}
+	return len(c.Data) > 0 && c.Data[0].Energy == c.Threshold && c.Data[0].Value == 0
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SaveCrossSections writes c to fileName in BOLSIG/LXCat format, preserving any injected
+// threshold point.
+func SaveCrossSections(fileName string, c Collisions) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = c.WriteTo(file)
+	return err
+}